@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// DryRunClient renders the request locally without contacting the API server.
+const DryRunClient = "client"
+
+// DryRunServer requests a server-side dry run. The svcat SDK client
+// (servicecatalog.SDK) does not support it: its Provision returns an error
+// for this mode and creates nothing.
+const DryRunServer = "server"
+
+// DryRunnable is a mixin for commands that support emitting the resource
+// they would otherwise create, via --dry-run and -o/--output, instead of
+// calling the API. A server dry run still goes through the client's
+// Provision, which with servicecatalog.SDK fails as described above.
+type DryRunnable struct {
+	DryRun       string
+	OutputFormat string
+}
+
+// NewDryRunnable creates a DryRunnable.
+func NewDryRunnable() *DryRunnable {
+	return &DryRunnable{}
+}
+
+// AddDryRunFlags adds the --dry-run and -o/--output flags to cmd.
+func (r *DryRunnable) AddDryRunFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&r.DryRun, "dry-run", "",
+		"Don't actually provision, just print the object that would be sent, one of: client, server")
+	cmd.Flags().StringVarP(&r.OutputFormat, "output", "o", "",
+		"The output format to use when --dry-run is set, one of: yaml, json")
+}
+
+// ValidateDryRun checks that --dry-run and --output were given valid values.
+func (r *DryRunnable) ValidateDryRun() error {
+	switch r.DryRun {
+	case "", DryRunClient, DryRunServer:
+	default:
+		return fmt.Errorf("invalid --dry-run value %q, must be one of: %s, %s", r.DryRun, DryRunClient, DryRunServer)
+	}
+
+	switch r.OutputFormat {
+	case "", "yaml", "json":
+	default:
+		return fmt.Errorf("invalid --output value %q, must be one of: yaml, json", r.OutputFormat)
+	}
+
+	return nil
+}
+
+// IsDryRun returns true if either form of --dry-run was requested.
+func (r *DryRunnable) IsDryRun() bool {
+	return r.DryRun != ""
+}
+
+// IsServerDryRun returns true if --dry-run=server was requested.
+func (r *DryRunnable) IsServerDryRun() bool {
+	return r.DryRun == DryRunServer
+}
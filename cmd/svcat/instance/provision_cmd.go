@@ -17,18 +17,25 @@ limitations under the License.
 package instance
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
 	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/parameters/render"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/multicluster"
 	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog/secrets"
 	"github.com/spf13/cobra"
 )
 
 type ProvisionCmd struct {
 	*command.Namespaced
 	*command.Waitable
+	*command.DryRunnable
 
 	InstanceName string
 	ExternalID   string
@@ -36,16 +43,35 @@ type ProvisionCmd struct {
 	PlanName     string
 	RawParams    []string
 	JsonParams   string
+	ParamsFiles  []string
+	ParamsDoc    string
 	Params       interface{}
 	RawSecrets   []string
 	Secrets      map[string]string
+	ParamsEngine string
+	SetVars      []string
+	ExtStrVars   []string
+
+	RawSecretParams   []string
+	SecretParams      []parameters.SecretParamRef
+	SecretDecryptSpec string
+
+	RawContext          []string
+	JSONContext         string
+	OriginatingIdentity string
+
+	KubeContexts []string
+	AllContexts  bool
+	cxt          *command.Context
 }
 
 // NewProvisionCmd builds a "svcat provision" command
 func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	provisionCmd := &ProvisionCmd{
-		Namespaced: command.NewNamespaced(cxt),
-		Waitable:   command.NewWaitable(),
+		Namespaced:  command.NewNamespaced(cxt),
+		Waitable:    command.NewWaitable(),
+		DryRunnable: command.NewDryRunnable(),
+		cxt:         cxt,
 	}
 	cmd := &cobra.Command{
 		Use:   "provision NAME --plan PLAN --class CLASS",
@@ -54,6 +80,16 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -p location=eastus -p sslEnforcement=disabled
   svcat provision wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
   svcat provision wordpress-mysql-instance --class mysqldb --plan free -s mysecret[dbparams]
+  svcat provision secure-instance --class mysqldb --plan secureDB --params-from-file values.yaml --params-from-file overrides.yaml --params-doc production
+  svcat provision secure-instance --class mysqldb --plan secureDB --params-engine gotemplate --set region=eastus --params-json '{
+    "firewallRules" : [
+        {
+            "name": "Allow{{ .Values.region }}",
+            "startIPAddress": "75.70.113.50",
+            "endIPAddress" : "75.70.113.131"
+        }
+    ]
+  }'
   svcat provision secure-instance --class mysqldb --plan secureDB --params-json '{
     "encrypt" : true,
     "firewallRules" : [
@@ -64,6 +100,10 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
         }
     ]
   }'
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --context region=eastus --originating-identity jdoe
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --dry-run=client -o yaml > instance.yaml
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --secret-param db.password=mysecret[dbparams]#{.password}
+  svcat provision wordpress-mysql-instance --class mysqldb --plan free --kube-contexts dev,stage,prod
 `),
 		PreRunE: command.PreRunE(provisionCmd),
 		RunE:    command.RunE(provisionCmd),
@@ -81,9 +121,34 @@ func NewProvisionCmd(cxt *command.Context) *cobra.Command {
 	cmd.Flags().StringSliceVarP(&provisionCmd.RawSecrets, "secret", "s", nil,
 		"Additional parameter, whose value is stored in a secret, to use when provisioning the service, format: SECRET[KEY]")
 	cmd.Flags().StringVar(&provisionCmd.JsonParams, "params-json", "",
-		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param")
+		"Additional parameters to use when provisioning the service, provided as a JSON object. Cannot be combined with --param. With --params-engine=jsonnet, this is the Jsonnet program itself and cannot be combined with --params-from-file")
+	cmd.Flags().StringSliceVar(&provisionCmd.ParamsFiles, "params-from-file", nil,
+		"Additional parameters to use when provisioning the service, loaded from a YAML or JSON file on disk. Can be repeated; later files take precedence. Merged with --param and --params-json")
+	cmd.Flags().StringVar(&provisionCmd.ParamsDoc, "params-doc", "",
+		"The name of the document to use, when a --params-from-file file contains multiple '---'-separated YAML documents. Defaults to merging every document in the file")
+	cmd.Flags().StringVar(&provisionCmd.ParamsEngine, "params-engine", "",
+		"Template-expand the parameters before provisioning, using the named engine: gotemplate or jsonnet")
+	cmd.Flags().StringSliceVar(&provisionCmd.SetVars, "set", nil,
+		"A variable to make available to --params-engine, format: NAME=VALUE. Can be repeated")
+	cmd.Flags().StringSliceVar(&provisionCmd.ExtStrVars, "ext-str", nil,
+		"A Jsonnet external string variable to make available to --params-engine=jsonnet, format: NAME=VALUE. Can be repeated")
+	cmd.Flags().StringSliceVar(&provisionCmd.RawContext, "context", nil,
+		"Additional OSB context key to send with the provision request, format: NAME=VALUE. Can be repeated. Cannot be combined with --context-json")
+	cmd.Flags().StringVar(&provisionCmd.JSONContext, "context-json", "",
+		"The OSB context object to send with the provision request, provided as a JSON object. Cannot be combined with --context")
+	cmd.Flags().StringVar(&provisionCmd.OriginatingIdentity, "originating-identity", "",
+		"The user identity for the X-Broker-API-Originating-Identity header, format: USERNAME. It is set on the provision options only; the ServiceInstance that is created does not carry it")
+	cmd.Flags().StringSliceVar(&provisionCmd.RawSecretParams, "secret-param", nil,
+		"Additional parameter whose value is read from a secret, optionally extracted and decrypted, to use when provisioning the service, format: paramPath=SECRET[KEY]#jsonpath. Can be repeated")
+	cmd.Flags().StringVar(&provisionCmd.SecretDecryptSpec, "secret-decrypt", "",
+		"Decrypt --secret-param values with the named provider before use, format: provider or provider:config")
+	cmd.Flags().StringSliceVar(&provisionCmd.KubeContexts, "kube-contexts", nil,
+		"Provision against every one of these kubeconfig contexts in parallel, aggregating the results, format: CONTEXT1,CONTEXT2. Cannot be combined with --all-contexts")
+	cmd.Flags().BoolVar(&provisionCmd.AllContexts, "all-contexts", false,
+		"Provision against every context in the kubeconfig in parallel, aggregating the results. Cannot be combined with --kube-contexts")
 	provisionCmd.AddNamespaceFlags(cmd.Flags(), false)
 	provisionCmd.AddWaitFlags(cmd)
+	provisionCmd.AddDryRunFlags(cmd)
 
 	return cmd
 }
@@ -94,48 +159,291 @@ func (c *ProvisionCmd) Validate(args []string) error {
 	}
 	c.InstanceName = args[0]
 
-	var err error
-
 	if c.JsonParams != "" && len(c.RawParams) > 0 {
 		return fmt.Errorf("--params-json cannot be used with --param")
 	}
 
-	if c.JsonParams != "" {
-		c.Params, err = parameters.ParseVariableJSON(c.JsonParams)
-		if err != nil {
-			return fmt.Errorf("invalid --params-json value (%s)", err)
+	var paramsSource []byte
+	if c.ParamsEngine == render.JsonnetEngineName && c.JsonParams != "" {
+		// Jsonnet is a superset of JSON, but it's not a subset: a real
+		// Jsonnet program (std.extVar calls, comments, computed fields, ...)
+		// isn't valid standalone JSON, so it can't be round-tripped through
+		// ParseVariableJSON/--params-from-file's YAML/JSON parsing like the
+		// other engines' input is. Feed --params-json to the engine as the
+		// raw Jsonnet program instead.
+		if len(c.ParamsFiles) > 0 {
+			return fmt.Errorf("--params-json cannot be combined with --params-from-file when --params-engine=jsonnet; --params-json is evaluated as the Jsonnet program itself")
 		}
+		paramsSource = []byte(c.JsonParams)
 	} else {
-		c.Params, err = parameters.ParseVariableAssignments(c.RawParams)
+		fileParams, err := parameters.ParseParamsFromFiles(c.ParamsFiles, c.ParamsDoc)
+		if err != nil {
+			return err
+		}
+
+		var overrides map[string]interface{}
+		if c.JsonParams != "" {
+			overrides, err = parameters.ParseVariableJSON(c.JsonParams)
+			if err != nil {
+				return fmt.Errorf("invalid --params-json value (%s)", err)
+			}
+		} else {
+			overrides, err = parameters.ParseVariableAssignments(c.RawParams)
+			if err != nil {
+				return fmt.Errorf("invalid --param value (%s)", err)
+			}
+		}
+
+		merged := parameters.MergeParams(fileParams, overrides)
+		paramsSource, err = json.Marshal(merged)
 		if err != nil {
-			return fmt.Errorf("invalid --param value (%s)", err)
+			return fmt.Errorf("failed to render parameters (%s)", err)
 		}
 	}
 
+	var err error
+	c.Params, err = c.renderParams(paramsSource)
+	if err != nil {
+		return err
+	}
+
 	c.Secrets, err = parameters.ParseKeyMaps(c.RawSecrets)
 	if err != nil {
 		return fmt.Errorf("invalid --secret value (%s)", err)
 	}
 
-	return nil
+	c.SecretParams, err = parameters.ParseSecretParams(c.RawSecretParams)
+	if err != nil {
+		return fmt.Errorf("invalid --secret-param value (%s)", err)
+	}
+
+	if c.JSONContext != "" && len(c.RawContext) > 0 {
+		return fmt.Errorf("--context-json cannot be used with --context")
+	}
+
+	if c.AllContexts && len(c.KubeContexts) > 0 {
+		return fmt.Errorf("--all-contexts cannot be used with --kube-contexts")
+	}
+
+	if c.DryRunnable == nil {
+		return nil
+	}
+	return c.ValidateDryRun()
+}
+
+// BuildContext assembles the OSB context object to send with the provision
+// request from --context/--context-json plus the Kubernetes identifiers
+// svcat fills in automatically, including app's cluster ID. Called fresh
+// per context in provisionMultiContext, since clusterid is specific to
+// app's own cluster.
+func (c *ProvisionCmd) BuildContext(app *svcat.App) (map[string]interface{}, error) {
+	clusterID, err := app.GetClusterID()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build provision context (%s)", err)
+	}
+
+	if c.JSONContext != "" {
+		ctx, err := parameters.ParseVariableJSON(c.JSONContext)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --context-json value (%s)", err)
+		}
+		built, err := servicecatalog.BuildContext(servicecatalog.ContextBuilderOptions{
+			RawContext:   stringifyMap(ctx),
+			Namespace:    c.Namespace,
+			ClusterID:    clusterID,
+			InstanceName: c.InstanceName,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// BuildContext takes string values only; put the --context-json
+		// values back as given, so numbers, booleans and nested objects
+		// keep their JSON types.
+		for k, v := range ctx {
+			built[k] = v
+		}
+		return built, nil
+	}
+
+	rawContext, err := parameters.ParseVariableAssignments(c.RawContext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --context value (%s)", err)
+	}
+	return servicecatalog.BuildContext(servicecatalog.ContextBuilderOptions{
+		RawContext:   stringifyMap(rawContext),
+		Namespace:    c.Namespace,
+		ClusterID:    clusterID,
+		InstanceName: c.InstanceName,
+	})
+}
+
+func stringifyMap(m map[string]interface{}) map[string]string {
+	s := make(map[string]string, len(m))
+	for k, v := range m {
+		s[k] = fmt.Sprintf("%v", v)
+	}
+	return s
+}
+
+// cloneParams returns a deep copy of params, so each context a provision
+// request fans out to can have --secret-param values injected into its own
+// copy without racing, or leaking, across the other contexts.
+func cloneParams(params map[string]interface{}) (map[string]interface{}, error) {
+	if len(params) == 0 {
+		return map[string]interface{}{}, nil
+	}
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	cloned := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &cloned); err != nil {
+		return nil, err
+	}
+	return cloned, nil
+}
+
+// renderParams template-expands paramsSource through the configured
+// --params-engine, using --set and --ext-str as the available variables, and
+// parses the result back into a parameter map. paramsSource is JSON for
+// every engine except jsonnet, which takes its own program syntax as input.
+// With no --params-engine selected, paramsSource is parsed as it is and
+// must already be JSON.
+func (c *ProvisionCmd) renderParams(paramsSource []byte) (interface{}, error) {
+	engine, err := render.NewEngine(c.ParamsEngine)
+	if err != nil {
+		return nil, err
+	}
+
+	vars, err := parameters.ParseVariableAssignments(append(append([]string{}, c.SetVars...), c.ExtStrVars...))
+	if err != nil {
+		return nil, fmt.Errorf("invalid --set or --ext-str value (%s)", err)
+	}
+	strVars := make(map[string]string, len(vars))
+	for k, v := range vars {
+		strVars[k] = fmt.Sprintf("%v", v)
+	}
+
+	renderedJSON, err := engine.Render(paramsSource, strVars)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --params-engine=%s rendering (%s)", c.ParamsEngine, err)
+	}
+
+	rendered := map[string]interface{}{}
+	if err := json.Unmarshal(renderedJSON, &rendered); err != nil {
+		return nil, fmt.Errorf("rendered parameters are not valid JSON (%s)", err)
+	}
+	return rendered, nil
 }
 
 func (c *ProvisionCmd) Run() error {
 	return c.Provision()
 }
 
-func (c *ProvisionCmd) Provision() error {
+// resolveSecretParams reads each --secret-param's referenced Secret key from
+// app's cluster, optionally decrypts it and extracts a JSONPath subfield,
+// and injects the result into params at its paramPath. Each context a
+// provision request fans out to has its own app, and therefore its own
+// Secrets, so this must be called fresh per context rather than once.
+func (c *ProvisionCmd) resolveSecretParams(app *svcat.App, params map[string]interface{}) error {
+	if len(c.SecretParams) == 0 {
+		return nil
+	}
+
+	decrypter, err := secrets.New(c.SecretDecryptSpec)
+	if err != nil {
+		return fmt.Errorf("invalid --secret-decrypt value (%s)", err)
+	}
+
+	for _, ref := range c.SecretParams {
+		value, err := app.GetSecretKey(c.Namespace, ref.SecretName, ref.SecretKey)
+		if err != nil {
+			return fmt.Errorf("invalid --secret-param value (%s)", err)
+		}
+
+		if decrypter != nil {
+			value, err = decrypter.Decrypt(value)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt --secret-param %s (%s)", ref.ParamPath, err)
+			}
+		}
+
+		var resolved interface{} = string(value)
+		if ref.JSONPath != "" {
+			resolved, err = secrets.ExtractJSONPath(value, ref.JSONPath)
+			if err != nil {
+				return fmt.Errorf("invalid --secret-param value (%s)", err)
+			}
+		}
+
+		parameters.SetPath(params, ref.ParamPath, resolved)
+	}
+	return nil
+}
+
+// buildProvisionOptions assembles the ProvisionOptions to send to a single
+// context's app, including that context's own resolution of --secret-param.
+// Called fresh per context in provisionMultiContext, since the params map
+// it returns is read from and mutated for app's cluster alone.
+func (c *ProvisionCmd) buildProvisionOptions(app *svcat.App) (*servicecatalog.ProvisionOptions, error) {
+	context, err := c.BuildContext(app)
+	if err != nil {
+		return nil, err
+	}
+
+	base, _ := c.Params.(map[string]interface{})
+	params, err := cloneParams(base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render parameters (%s)", err)
+	}
+	if err := c.resolveSecretParams(app, params); err != nil {
+		return nil, err
+	}
+
 	opts := &servicecatalog.ProvisionOptions{
 		ExternalID: c.ExternalID,
 		Namespace:  c.Namespace,
-		Params:     c.Params,
+		Params:     params,
 		Secrets:    c.Secrets,
+		Context:    context,
 	}
+	if c.OriginatingIdentity != "" {
+		opts.OriginatingIdentity = servicecatalog.BuildOriginatingIdentity(servicecatalog.PlatformKubernetes, c.OriginatingIdentity, nil)
+	}
+	if c.IsServerDryRun() {
+		opts.DryRun = command.DryRunServer
+	}
+	return opts, nil
+}
+
+func (c *ProvisionCmd) Provision() error {
+	if c.AllContexts || len(c.KubeContexts) > 0 {
+		return c.provisionMultiContext()
+	}
+
+	opts, err := c.buildProvisionOptions(c.App)
+	if err != nil {
+		return err
+	}
+
+	if c.DryRun == command.DryRunClient {
+		instance, err := servicecatalog.BuildServiceInstance(c.InstanceName, c.ClassName, c.PlanName, opts)
+		if err != nil {
+			return err
+		}
+		return output.WriteInstanceManifest(c.Output, instance, c.OutputFormat)
+	}
+
 	instance, err := c.App.Provision(c.InstanceName, c.ClassName, c.PlanName, opts)
 	if err != nil {
 		return err
 	}
 
+	if c.IsServerDryRun() {
+		return output.WriteInstanceManifest(c.Output, instance, c.OutputFormat)
+	}
+
 	if c.Wait {
 		fmt.Fprintln(c.Output, "Waiting for the instance to be provisioned...")
 		finalInstance, err := c.App.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
@@ -152,3 +460,69 @@ func (c *ProvisionCmd) Provision() error {
 	output.WriteInstanceDetails(c.Output, instance)
 	return nil
 }
+
+// provisionMultiContext provisions the instance against every context in
+// c.KubeContexts (or every context in the kubeconfig, for --all-contexts),
+// in parallel, printing one instance per context and aggregating errors.
+// Each context builds its own App and its own ProvisionOptions, so a
+// --secret-param is read from (and a manifest is built against) that
+// context's own cluster, instead of being resolved once and reused
+// everywhere.
+func (c *ProvisionCmd) provisionMultiContext() error {
+	contexts := c.KubeContexts
+	if c.AllContexts {
+		all, err := svcat.ListKubeContexts(c.cxt.KubeConfig)
+		if err != nil {
+			return fmt.Errorf("unable to list kubeconfig contexts for --all-contexts (%s)", err)
+		}
+		contexts = all
+	}
+
+	orchestrator := multicluster.New(func(kubeContext string) (*svcat.App, error) {
+		return svcat.NewAppForContext(c.cxt.KubeConfig, kubeContext, c.Namespace)
+	})
+
+	results := orchestrator.Run(contexts, func(app *svcat.App) (interface{}, error) {
+		opts, err := c.buildProvisionOptions(app)
+		if err != nil {
+			return nil, err
+		}
+
+		if c.DryRun == command.DryRunClient {
+			return servicecatalog.BuildServiceInstance(c.InstanceName, c.ClassName, c.PlanName, opts)
+		}
+
+		instance, err := app.Provision(c.InstanceName, c.ClassName, c.PlanName, opts)
+		if err != nil {
+			return nil, err
+		}
+		if c.IsServerDryRun() {
+			return instance, nil
+		}
+		if c.Wait {
+			instance, err = app.WaitForInstance(instance.Namespace, instance.Name, c.Interval, c.Timeout)
+		}
+		return instance, err
+	})
+
+	for _, r := range results {
+		fmt.Fprintf(c.Output, "context %q:\n", r.Context)
+		if r.Err != nil {
+			fmt.Fprintln(c.Output, r.Err)
+			continue
+		}
+		instance := r.Value.(*v1beta1.ServiceInstance)
+		if c.IsDryRun() {
+			if err := output.WriteInstanceManifest(c.Output, instance, c.OutputFormat); err != nil {
+				return err
+			}
+			continue
+		}
+		output.WriteInstanceDetails(c.Output, instance)
+	}
+
+	if errs := multicluster.Errors(results); len(errs) > 0 {
+		return fmt.Errorf("provision failed in %d of %d contexts", len(errs), len(contexts))
+	}
+	return nil
+}
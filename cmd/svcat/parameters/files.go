@@ -0,0 +1,181 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+)
+
+// refKey is the JSON/YAML key used to pull in another params file relative
+// to the file that references it, e.g. {"$ref": "./common.yaml"}.
+const refKey = "$ref"
+
+// ParseParamsFromFiles reads one or more YAML or JSON files containing
+// provisioning parameters and deep-merges them, in order, into a single
+// parameter map. Later files take precedence over earlier ones.
+//
+// Files containing multiple "---"-separated YAML documents are resolved by
+// doc, which selects a single document by name (matching its top level
+// "name" key) or, if empty, merges every document in the file in order.
+func ParseParamsFromFiles(paths []string, doc string) (map[string]interface{}, error) {
+	return parseParamsFromFiles(paths, doc, map[string]bool{})
+}
+
+func parseParamsFromFiles(paths []string, doc string, seen map[string]bool) (map[string]interface{}, error) {
+	merged := map[string]interface{}{}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --params-from-file value (%s)", err)
+		}
+		if seen[abs] {
+			return nil, fmt.Errorf("invalid --params-from-file value (circular $ref back to %s)", path)
+		}
+		seen[abs] = true
+
+		docs, err := loadParamDocs(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --params-from-file value (%s)", err)
+		}
+
+		selected, err := selectParamDocs(docs, doc)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --params-from-file value (%s)", err)
+		}
+
+		for _, d := range selected {
+			resolved, err := resolveRefs(filepath.Dir(path), d, seen)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --params-from-file value (%s)", err)
+			}
+			merged = deepMergeMaps(merged, resolved)
+		}
+
+		delete(seen, abs)
+	}
+	return merged, nil
+}
+
+// loadParamDocs reads path and splits it into one or more parameter maps,
+// sniffing the format by extension and, failing that, by content.
+func loadParamDocs(path string) ([]map[string]interface{}, error) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if looksLikeJSON(path, raw) {
+		doc, err := ParseVariableJSON(string(raw))
+		if err != nil {
+			return nil, err
+		}
+		return []map[string]interface{}{doc}, nil
+	}
+
+	var docs []map[string]interface{}
+	for _, chunk := range strings.Split(string(raw), "\n---") {
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		doc := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(chunk), &doc); err != nil {
+			return nil, fmt.Errorf("%s: %s", path, err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+func looksLikeJSON(path string, raw []byte) bool {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return true
+	}
+	trimmed := strings.TrimSpace(string(raw))
+	return strings.HasPrefix(trimmed, "{")
+}
+
+// selectParamDocs picks a single document by its "name" key, or returns every
+// document when name is empty.
+func selectParamDocs(docs []map[string]interface{}, name string) ([]map[string]interface{}, error) {
+	if name == "" {
+		return docs, nil
+	}
+	for _, d := range docs {
+		if d["name"] == name {
+			return []map[string]interface{}{d}, nil
+		}
+	}
+	return nil, fmt.Errorf("no document named %q", name)
+}
+
+// resolveRefs replaces a top-level "$ref": "relative/path" entry in doc with
+// the parsed contents of the referenced file, resolved relative to baseDir.
+// "$ref" keys inside nested maps are left as they are.
+// seen tracks the absolute paths of files on the current $ref chain, so a
+// file that (directly or transitively) refs back to itself is reported as a
+// circular $ref instead of recursing forever.
+func resolveRefs(baseDir string, doc map[string]interface{}, seen map[string]bool) (map[string]interface{}, error) {
+	ref, ok := doc[refKey].(string)
+	if !ok {
+		return doc, nil
+	}
+
+	included, err := parseParamsFromFiles([]string{filepath.Join(baseDir, ref)}, "", seen)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := map[string]interface{}{}
+	for k, v := range doc {
+		if k == refKey {
+			continue
+		}
+		resolved[k] = v
+	}
+	return deepMergeMaps(included, resolved), nil
+}
+
+// MergeParams deep-merges override into base and returns the result,
+// without modifying either argument. override wins on conflicting keys.
+func MergeParams(base, override map[string]interface{}) map[string]interface{} {
+	return deepMergeMaps(base, override)
+}
+
+// deepMergeMaps merges override into base, recursively merging any values
+// that are themselves maps, and returns the result. override wins on
+// conflicting scalar keys.
+func deepMergeMaps(base, override map[string]interface{}) map[string]interface{} {
+	merged := map[string]interface{}{}
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		if baseVal, ok := merged[k].(map[string]interface{}); ok {
+			if overrideVal, ok := v.(map[string]interface{}); ok {
+				merged[k] = deepMergeMaps(baseVal, overrideVal)
+				continue
+			}
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseParamsFromFiles", func() {
+	It("errors on a file that $refs back to itself", func() {
+		dir, err := ioutil.TempDir("", "svcat-files-test")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "a.yaml")
+		Expect(ioutil.WriteFile(path, []byte("$ref: a.yaml\n"), 0644)).To(Succeed())
+
+		_, err = ParseParamsFromFiles([]string{path}, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("circular $ref"))
+	})
+
+	It("errors on a $ref cycle spanning two files", func() {
+		dir, err := ioutil.TempDir("", "svcat-files-test")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		aPath := filepath.Join(dir, "a.yaml")
+		bPath := filepath.Join(dir, "b.yaml")
+		Expect(ioutil.WriteFile(aPath, []byte("$ref: b.yaml\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(bPath, []byte("$ref: a.yaml\n"), 0644)).To(Succeed())
+
+		_, err = ParseParamsFromFiles([]string{aPath}, "")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("circular $ref"))
+	})
+
+	It("allows the same file to be $ref'd from two unrelated places", func() {
+		dir, err := ioutil.TempDir("", "svcat-files-test")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		commonPath := filepath.Join(dir, "common.yaml")
+		aPath := filepath.Join(dir, "a.yaml")
+		bPath := filepath.Join(dir, "b.yaml")
+		Expect(ioutil.WriteFile(commonPath, []byte("region: eastus\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(aPath, []byte("$ref: common.yaml\nfoo: bar\n"), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(bPath, []byte("$ref: common.yaml\nbaz: qux\n"), 0644)).To(Succeed())
+
+		merged, err := ParseParamsFromFiles([]string{aPath, bPath}, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(merged["region"]).To(Equal("eastus"))
+		Expect(merged["foo"]).To(Equal("bar"))
+		Expect(merged["baz"]).To(Equal("qux"))
+	})
+})
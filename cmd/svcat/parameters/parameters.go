@@ -0,0 +1,71 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package parameters contains helpers shared by the svcat commands that
+// accept provisioning/binding parameters, such as parsing NAME=VALUE
+// assignments, JSON blobs, and MAP[KEY] secret references.
+package parameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ParseVariableAssignments parses NAME=VALUE strings into a map of keys and values
+func ParseVariableAssignments(params []string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	for _, p := range params {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid parameter (%s), must be in NAME=VALUE format", p)
+		}
+		variables[parts[0]] = parts[1]
+	}
+	return variables, nil
+}
+
+// ParseVariableJSON parses a JSON string into a map of keys and values
+func ParseVariableJSON(jsonParams string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	err := json.Unmarshal([]byte(jsonParams), &variables)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameters (%s)", jsonParams)
+	}
+	return variables, nil
+}
+
+// ParseKeyMaps parses MAP[KEY] strings into a map of keys and values
+func ParseKeyMaps(params []string) (map[string]string, error) {
+	keyMaps := make(map[string]string)
+	for _, p := range params {
+		mapName, key, err := parseKeyMap(p)
+		if err != nil {
+			return nil, err
+		}
+		keyMaps[mapName] = key
+	}
+	return keyMaps, nil
+}
+
+func parseKeyMap(param string) (mapName string, key string, err error) {
+	start := strings.Index(param, "[")
+	end := strings.Index(param, "]")
+	if start <= 0 || end != len(param)-1 {
+		return "", "", fmt.Errorf("invalid parameter (%s), must be in MAP[KEY] format", param)
+	}
+	return param[:start], param[start+1 : end], nil
+}
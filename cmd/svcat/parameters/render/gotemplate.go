@@ -0,0 +1,65 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// goTemplateEngine renders the parameter tree with Go's text/template,
+// exposing .Values (the vars passed to Render; svcat provision passes its
+// --set and --ext-str variables) and a handful of sprig-like
+// convenience functions.
+type goTemplateEngine struct{}
+
+func (goTemplateEngine) Render(paramsJSON []byte, vars map[string]string) ([]byte, error) {
+	tmpl, err := template.New("params").Funcs(templateFuncs()).Parse(string(paramsJSON))
+	if err != nil {
+		return nil, fmt.Errorf("invalid parameter template (%s)", err)
+	}
+
+	data := struct {
+		Values map[string]string
+	}{Values: vars}
+
+	var rendered bytes.Buffer
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return nil, fmt.Errorf("failed to render parameter template (%s)", err)
+	}
+	return rendered.Bytes(), nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"env": os.Getenv,
+		"default": func(def, val string) string {
+			if val == "" {
+				return def
+			}
+			return val
+		},
+		"quote": func(s string) string {
+			return fmt.Sprintf("%q", s)
+		},
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
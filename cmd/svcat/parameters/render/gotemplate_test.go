@@ -0,0 +1,42 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("goTemplateEngine", func() {
+	It("substitutes .Values from vars into the JSON document", func() {
+		out, err := goTemplateEngine{}.Render([]byte(`{"region": "{{ .Values.region }}"}`), map[string]string{"region": "eastus"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"region": "eastus"}`))
+	})
+
+	It("exposes the default/upper/quote helper functions", func() {
+		out, err := goTemplateEngine{}.Render([]byte(`{"name": {{ .Values.name | default "fallback" | upper | quote }}}`), map[string]string{"name": ""})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"name": "FALLBACK"}`))
+	})
+
+	It("errors on an invalid template", func() {
+		_, err := goTemplateEngine{}.Render([]byte(`{"region": {{ .Values.region }`), nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("invalid parameter template"))
+	})
+})
@@ -0,0 +1,43 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+
+	"github.com/google/go-jsonnet"
+)
+
+// jsonnetEngine treats the parameter tree as a Jsonnet program, following
+// the kubecfg/kartongips convention of generating structured parameters
+// (class/plan selection, secret references, IP-range arrays, ...) from a
+// small program rather than a static document. vars are bound as Jsonnet
+// external strings, reachable from the program via std.extVar.
+type jsonnetEngine struct{}
+
+func (jsonnetEngine) Render(paramsJSON []byte, vars map[string]string) ([]byte, error) {
+	vm := jsonnet.MakeVM()
+	for name, value := range vars {
+		vm.ExtVar(name, value)
+	}
+
+	rendered, err := vm.EvaluateSnippet("params", string(paramsJSON))
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate jsonnet parameters (%s)", err)
+	}
+	return []byte(rendered), nil
+}
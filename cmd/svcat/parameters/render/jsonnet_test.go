@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("jsonnetEngine", func() {
+	It("evaluates a plain JSON document unchanged", func() {
+		out, err := jsonnetEngine{}.Render([]byte(`{"region": "eastus"}`), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"region": "eastus"}`))
+	})
+
+	It("evaluates a real Jsonnet program, not just a JSON document", func() {
+		program := `
+local region = std.extVar("region");
+{
+  region: region,
+  firewallRules: [
+    { name: "Allow" + region, startIPAddress: "10.0.0.0", endIPAddress: "10.0.0.255" },
+  ],
+}`
+		out, err := jsonnetEngine{}.Render([]byte(program), map[string]string{"region": "eastus"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{
+			"region": "eastus",
+			"firewallRules": [
+				{"name": "Alloweastus", "startIPAddress": "10.0.0.0", "endIPAddress": "10.0.0.255"}
+			]
+		}`))
+	})
+
+	It("errors on invalid Jsonnet", func() {
+		_, err := jsonnetEngine{}.Render([]byte(`{`), nil)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to evaluate jsonnet parameters"))
+	})
+})
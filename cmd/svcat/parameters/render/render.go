@@ -0,0 +1,57 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package render template-expands provisioning/binding parameters before
+// they're submitted to the broker. A parameter tree is serialized to JSON,
+// fed through a pluggable Engine along with a set of caller-supplied
+// variables, and the rendered JSON is parsed back into a parameter tree.
+package render
+
+import "fmt"
+
+// GoTemplateEngineName selects the text/template-based engine.
+const GoTemplateEngineName = "gotemplate"
+
+// JsonnetEngineName selects the embedded Jsonnet engine.
+const JsonnetEngineName = "jsonnet"
+
+// Engine expands a parameter tree, serialized as JSON, against a set of
+// variables and returns the rendered JSON.
+type Engine interface {
+	Render(paramsJSON []byte, vars map[string]string) ([]byte, error)
+}
+
+// NewEngine returns the Engine registered under name. An empty name selects
+// the no-op engine, so callers that never pass --params-engine continue to
+// submit parameters unmodified.
+func NewEngine(name string) (Engine, error) {
+	switch name {
+	case "":
+		return noopEngine{}, nil
+	case GoTemplateEngineName:
+		return goTemplateEngine{}, nil
+	case JsonnetEngineName:
+		return jsonnetEngine{}, nil
+	default:
+		return nil, fmt.Errorf("unknown --params-engine %q, must be one of: %s, %s", name, GoTemplateEngineName, JsonnetEngineName)
+	}
+}
+
+type noopEngine struct{}
+
+func (noopEngine) Render(paramsJSON []byte, vars map[string]string) ([]byte, error) {
+	return paramsJSON, nil
+}
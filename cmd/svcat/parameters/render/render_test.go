@@ -0,0 +1,50 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewEngine", func() {
+	It("returns the no-op engine for an empty name", func() {
+		engine, err := NewEngine("")
+		Expect(err).NotTo(HaveOccurred())
+		out, err := engine.Render([]byte(`{"foo":"bar"}`), nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out).To(MatchJSON(`{"foo":"bar"}`))
+	})
+
+	It("returns the gotemplate engine", func() {
+		engine, err := NewEngine(GoTemplateEngineName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(engine).To(Equal(goTemplateEngine{}))
+	})
+
+	It("returns the jsonnet engine", func() {
+		engine, err := NewEngine(JsonnetEngineName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(engine).To(Equal(jsonnetEngine{}))
+	})
+
+	It("errors on an unknown engine name", func() {
+		_, err := NewEngine("xslt")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown --params-engine "xslt"`))
+	})
+})
@@ -0,0 +1,81 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SecretParamRef describes one --secret-param paramPath=SECRET[KEY]#jsonpath
+// value: the dotted path within the parameter tree to fill in, the secret
+// and key to read, and an optional JSONPath to pull a subfield out of that
+// key's value.
+type SecretParamRef struct {
+	ParamPath  string
+	SecretName string
+	SecretKey  string
+	JSONPath   string
+}
+
+// ParseSecretParams parses --secret-param values in
+// "paramPath=SECRET[KEY]#jsonpath" format. The "#jsonpath" suffix is
+// optional; without it the whole secret value is used.
+func ParseSecretParams(params []string) ([]SecretParamRef, error) {
+	refs := make([]SecretParamRef, 0, len(params))
+	for _, p := range params {
+		parts := strings.SplitN(p, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid parameter (%s), must be in paramPath=SECRET[KEY]#jsonpath format", p)
+		}
+		paramPath, ref := parts[0], parts[1]
+
+		ref, jsonPath := ref, ""
+		if i := strings.LastIndex(ref, "#"); i >= 0 {
+			ref, jsonPath = ref[:i], ref[i+1:]
+		}
+
+		secretName, secretKey, err := parseKeyMap(ref)
+		if err != nil {
+			return nil, fmt.Errorf("invalid parameter (%s), must be in paramPath=SECRET[KEY]#jsonpath format: %s", p, err)
+		}
+
+		refs = append(refs, SecretParamRef{
+			ParamPath:  paramPath,
+			SecretName: secretName,
+			SecretKey:  secretKey,
+			JSONPath:   jsonPath,
+		})
+	}
+	return refs, nil
+}
+
+// SetPath sets value at the dot-separated path within tree, creating any
+// intermediate maps that don't already exist.
+func SetPath(tree map[string]interface{}, path string, value interface{}) {
+	segments := strings.Split(path, ".")
+	node := tree
+	for _, segment := range segments[:len(segments)-1] {
+		child, ok := node[segment].(map[string]interface{})
+		if !ok {
+			child = map[string]interface{}{}
+			node[segment] = child
+		}
+		node = child
+	}
+	node[segments[len(segments)-1]] = value
+}
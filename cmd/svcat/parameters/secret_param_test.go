@@ -0,0 +1,57 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parameters
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ParseSecretParams", func() {
+	It("parses paramPath=SECRET[KEY]#jsonpath into a SecretParamRef", func() {
+		refs, err := ParseSecretParams([]string{"db.password=mysecret[dbparams]#{.password}"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs).To(Equal([]SecretParamRef{{
+			ParamPath:  "db.password",
+			SecretName: "mysecret",
+			SecretKey:  "dbparams",
+			JSONPath:   "{.password}",
+		}}))
+	})
+
+	It("leaves JSONPath empty when the #jsonpath suffix is omitted", func() {
+		refs, err := ParseSecretParams([]string{"apiKey=mysecret[apiKey]"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(refs[0].JSONPath).To(BeEmpty())
+	})
+
+	It("errors on a value with no SECRET[KEY] reference", func() {
+		_, err := ParseSecretParams([]string{"nomaprefhere"})
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("SetPath", func() {
+	It("sets a dotted path, creating intermediate maps as needed", func() {
+		tree := map[string]interface{}{}
+		SetPath(tree, "db.password", "hunter2")
+
+		db, ok := tree["db"].(map[string]interface{})
+		Expect(ok).To(BeTrue())
+		Expect(db["password"]).To(Equal("hunter2"))
+	})
+})
@@ -0,0 +1,93 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package multicluster fans a single svcat invocation out across several
+// kubeconfig contexts, so commands like provision can be run once against
+// dev/stage/prod (or any other set of clusters) instead of being repeated
+// by hand per context.
+package multicluster
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+)
+
+// AppBuilder constructs an App scoped to a single kubeconfig context. svcat
+// provides one backed by svcat.NewAppForContext, the context-parameterized
+// sibling of svcat.NewApp's current-context default.
+type AppBuilder func(kubeContext string) (*svcat.App, error)
+
+// Orchestrator runs an operation against every target context, in parallel,
+// and aggregates the results.
+type Orchestrator struct {
+	NewApp AppBuilder
+}
+
+// New creates an Orchestrator that builds one App per context via newApp.
+func New(newApp AppBuilder) *Orchestrator {
+	return &Orchestrator{NewApp: newApp}
+}
+
+// Result is one context's outcome from Run.
+type Result struct {
+	Context string
+	Value   interface{}
+	Err     error
+}
+
+// Run calls do, with the App for each context, once per context in
+// contexts, in parallel, and returns one Result per context in the same
+// order. A failure in one context's App construction or do does not stop
+// the others from running.
+func (o *Orchestrator) Run(contexts []string, do func(app *svcat.App) (interface{}, error)) []Result {
+	results := make([]Result, len(contexts))
+
+	var wg sync.WaitGroup
+	for i, kubeContext := range contexts {
+		wg.Add(1)
+		go func(i int, kubeContext string) {
+			defer wg.Done()
+
+			app, err := o.NewApp(kubeContext)
+			if err != nil {
+				results[i] = Result{Context: kubeContext, Err: fmt.Errorf("context %q: %s", kubeContext, err)}
+				return
+			}
+
+			value, err := do(app)
+			if err != nil {
+				err = fmt.Errorf("context %q: %s", kubeContext, err)
+			}
+			results[i] = Result{Context: kubeContext, Value: value, Err: err}
+		}(i, kubeContext)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// Errors collects the non-nil errors out of results, in context order.
+func Errors(results []Result) []error {
+	var errs []error
+	for _, r := range results {
+		if r.Err != nil {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multicluster
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/svcat"
+)
+
+var _ = Describe("Orchestrator.Run", func() {
+	It("aggregates per-context results, isolating a broken context's error from the rest", func() {
+		o := New(func(kubeContext string) (*svcat.App, error) {
+			if kubeContext == "broken" {
+				return nil, fmt.Errorf("no such context")
+			}
+			return &svcat.App{}, nil
+		})
+
+		results := o.Run([]string{"dev", "broken", "prod"}, func(app *svcat.App) (interface{}, error) {
+			return "ok", nil
+		})
+
+		Expect(results).To(HaveLen(3))
+
+		Expect(results[0].Err).NotTo(HaveOccurred())
+		Expect(results[0].Value).To(Equal("ok"))
+
+		Expect(results[1].Err).To(HaveOccurred())
+
+		Expect(results[2].Err).NotTo(HaveOccurred())
+		Expect(results[2].Value).To(Equal("ok"))
+
+		Expect(Errors(results)).To(HaveLen(1))
+	})
+})
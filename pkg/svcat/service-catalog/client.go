@@ -0,0 +1,105 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	clientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+// SvcatClient is the set of service-catalog operations svcat's commands
+// drive through an App. SDK is the only production implementation;
+// commands are tested against service-catalogfakes.FakeSvcatClient.
+type SvcatClient interface {
+	Provision(instanceName, className, planName string, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error)
+	WaitForInstance(namespace, name string, interval, timeout time.Duration) (*v1beta1.ServiceInstance, error)
+	GetSecretKey(namespace, secretName, key string) ([]byte, error)
+	GetClusterID() (string, error)
+}
+
+// SDK is the default SvcatClient, backed by a real service-catalog
+// clientset and a real Kubernetes clientset for reading Secrets.
+type SDK struct {
+	ServiceCatalogClient clientset.Interface
+	K8sClient            kubernetes.Interface
+}
+
+// Provision creates the ServiceInstance described by opts.
+func (sdk *SDK) Provision(instanceName, className, planName string, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
+	if opts.DryRun != "" {
+		// This package's generated clientset's Create doesn't take a
+		// CreateOptions to carry a server-side dryRun through, so there's
+		// no way to honor this without actually persisting the instance.
+		// Fail clearly instead of silently creating it for real.
+		return nil, fmt.Errorf("server-side dry-run is not supported by this client; use --dry-run=client instead")
+	}
+
+	instance, err := BuildServiceInstance(instanceName, className, planName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return sdk.ServiceCatalogClient.ServicecatalogV1beta1().ServiceInstances(opts.Namespace).Create(instance)
+}
+
+// WaitForInstance polls the ServiceInstance at namespace/name until it
+// reports ready or failed, or timeout elapses.
+func (sdk *SDK) WaitForInstance(namespace, name string, interval, timeout time.Duration) (*v1beta1.ServiceInstance, error) {
+	var instance *v1beta1.ServiceInstance
+	err := wait.PollImmediate(interval, timeout, func() (bool, error) {
+		var err error
+		instance, err = sdk.ServiceCatalogClient.ServicecatalogV1beta1().ServiceInstances(namespace).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		return instance.Status.AsyncOpInProgress == false && len(instance.Status.Conditions) > 0, nil
+	})
+	return instance, err
+}
+
+// GetSecretKey reads the Secret named secretName in namespace and returns
+// the raw value stored under key.
+func (sdk *SDK) GetSecretKey(namespace, secretName, key string) ([]byte, error) {
+	secret, err := sdk.K8sClient.CoreV1().Secrets(namespace).Get(secretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secret %s/%s (%s)", namespace, secretName, err)
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s/%s has no key %q", namespace, secretName, key)
+	}
+	return value, nil
+}
+
+// GetClusterID returns a stable identifier for the cluster sdk is talking
+// to, for the OSB context object's "clusterid" key: the UID of the
+// kube-system namespace, which is set once at cluster creation and never
+// changes, the same identifier tools like Velero use for this purpose.
+func (sdk *SDK) GetClusterID() (string, error) {
+	ns, err := sdk.K8sClient.CoreV1().Namespaces().Get("kube-system", metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to determine cluster ID (%s)", err)
+	}
+	return string(ns.UID), nil
+}
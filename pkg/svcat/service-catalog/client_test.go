@@ -0,0 +1,96 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+
+	scfake "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+)
+
+var _ = Describe("SDK.GetSecretKey", func() {
+	var sdk *SDK
+
+	BeforeEach(func() {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "db-creds", Namespace: "prod"},
+			Data:       map[string][]byte{"password": []byte("hunter2")},
+		}
+		sdk = &SDK{K8sClient: k8sfake.NewSimpleClientset(secret)}
+	})
+
+	It("returns the raw value stored under key", func() {
+		value, err := sdk.GetSecretKey("prod", "db-creds", "password")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]byte("hunter2")))
+	})
+
+	It("errors if the secret doesn't exist", func() {
+		_, err := sdk.GetSecretKey("prod", "no-such-secret", "password")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("errors if the key isn't present in the secret", func() {
+		_, err := sdk.GetSecretKey("prod", "db-creds", "no-such-key")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("SDK.GetClusterID", func() {
+	It("returns the kube-system namespace UID", func() {
+		kubeSystem := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "kube-system", UID: "abc-123"},
+		}
+		sdk := &SDK{K8sClient: k8sfake.NewSimpleClientset(kubeSystem)}
+
+		id, err := sdk.GetClusterID()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(id).To(Equal("abc-123"))
+	})
+
+	It("errors if kube-system can't be read", func() {
+		sdk := &SDK{K8sClient: k8sfake.NewSimpleClientset()}
+
+		_, err := sdk.GetClusterID()
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("SDK.Provision", func() {
+	It("creates the ServiceInstance", func() {
+		scClient := scfake.NewSimpleClientset()
+		sdk := &SDK{ServiceCatalogClient: scClient}
+
+		instance, err := sdk.Provision("wordpress", "mysqldb", "free", &ProvisionOptions{Namespace: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.Name).To(Equal("wordpress"))
+		Expect(scClient.Fake.Actions()).To(HaveLen(1))
+	})
+
+	It("errors instead of creating anything, when server-side dry-run is requested", func() {
+		scClient := scfake.NewSimpleClientset()
+		sdk := &SDK{ServiceCatalogClient: scClient}
+
+		_, err := sdk.Provision("wordpress", "mysqldb", "free", &ProvisionOptions{Namespace: "default", DryRun: "server"})
+		Expect(err).To(HaveOccurred())
+		Expect(scClient.Fake.Actions()).To(BeEmpty())
+	})
+})
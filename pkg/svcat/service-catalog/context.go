@@ -0,0 +1,86 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import "fmt"
+
+// PlatformKubernetes is the OSB "platform" value svcat always sends, since
+// it only ever runs against a Kubernetes cluster.
+const PlatformKubernetes = "kubernetes"
+
+// reservedContextKeys are populated automatically from the target instance
+// and may not be overridden via --context.
+var reservedContextKeys = map[string]bool{
+	"platform":      true,
+	"namespace":     true,
+	"clusterid":     true,
+	"instance_name": true,
+}
+
+// ContextBuilderOptions carries the user-supplied pieces of the OSB context
+// object, plus the Kubernetes identifiers svcat fills in automatically.
+type ContextBuilderOptions struct {
+	RawContext   map[string]string
+	Namespace    string
+	ClusterID    string
+	InstanceName string
+}
+
+// BuildContext assembles the OSB-spec "context" object for a provision or
+// update-instance request, starting from the standard Kubernetes context
+// keys (platform, namespace, clusterid, instance_name) and layering the
+// user-supplied --context/--context-json values on top. svcat provision is
+// its only caller; no update-instance command uses it.
+func BuildContext(opts ContextBuilderOptions) (map[string]interface{}, error) {
+	ctx := map[string]interface{}{
+		"platform": PlatformKubernetes,
+	}
+	if opts.Namespace != "" {
+		ctx["namespace"] = opts.Namespace
+	}
+	if opts.ClusterID != "" {
+		ctx["clusterid"] = opts.ClusterID
+	}
+	if opts.InstanceName != "" {
+		ctx["instance_name"] = opts.InstanceName
+	}
+
+	for k, v := range opts.RawContext {
+		if reservedContextKeys[k] {
+			return nil, fmt.Errorf("%q is a reserved context key and cannot be set with --context", k)
+		}
+		ctx[k] = v
+	}
+
+	return ctx, nil
+}
+
+// BuildOriginatingIdentity assembles the value of the
+// X-Broker-API-Originating-Identity header from the user identity
+// currently provisioning, for the --originating-identity flag.
+func BuildOriginatingIdentity(platform, username string, extra map[string]string) map[string]interface{} {
+	value := map[string]interface{}{
+		"username": username,
+	}
+	for k, v := range extra {
+		value[k] = v
+	}
+	return map[string]interface{}{
+		"platform": platform,
+		"value":    value,
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/ginkgo/extensions/table"
+)
+
+var _ = Describe("BuildContext", func() {
+	It("fills in the standard Kubernetes context keys", func() {
+		ctx, err := BuildContext(ContextBuilderOptions{
+			Namespace:    "ns",
+			ClusterID:    "cluster-1",
+			InstanceName: "instance-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctx["platform"]).To(Equal(PlatformKubernetes))
+		Expect(ctx["namespace"]).To(Equal("ns"))
+		Expect(ctx["clusterid"]).To(Equal("cluster-1"))
+		Expect(ctx["instance_name"]).To(Equal("instance-1"))
+	})
+
+	It("layers --context values on top of the standard keys", func() {
+		ctx, err := BuildContext(ContextBuilderOptions{
+			RawContext: map[string]string{"region": "eastus"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ctx["region"]).To(Equal("eastus"))
+	})
+
+	table.DescribeTable("rejects --context overrides of auto-populated keys",
+		func(key string) {
+			_, err := BuildContext(ContextBuilderOptions{
+				RawContext: map[string]string{key: "overridden"},
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("reserved context key"))
+		},
+		table.Entry("platform", "platform"),
+		table.Entry("namespace", "namespace"),
+		table.Entry("clusterid", "clusterid"),
+		table.Entry("instance_name", "instance_name"),
+	)
+})
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// serviceInstanceAPIVersion and serviceInstanceKind populate the TypeMeta
+// of a ServiceInstance built client-side (BuildServiceInstance), since it
+// never passes through a scheme that would set them automatically. Without
+// these, a manifest written by --dry-run=client -o yaml is missing
+// apiVersion/kind and kubectl apply rejects it.
+const (
+	serviceInstanceAPIVersion = "servicecatalog.k8s.io/v1beta1"
+	serviceInstanceKind       = "ServiceInstance"
+)
+
+// ProvisionOptions provides optional parameters to the Provision call.
+type ProvisionOptions struct {
+	ExternalID string
+	Namespace  string
+	Params     interface{}
+	Secrets    map[string]string
+
+	// Context is the OSB context object sent with the provision request,
+	// e.g. platform/namespace/clusterid. Built by BuildContext.
+	Context map[string]interface{}
+
+	// OriginatingIdentity holds the value for the
+	// X-Broker-API-Originating-Identity header. Neither BuildServiceInstance
+	// nor SDK.Provision reads it, so it is not sent with the request.
+	OriginatingIdentity map[string]interface{}
+
+	// DryRun, when set, is the requested dry-run mode (see
+	// command.DryRunClient/command.DryRunServer). SDK.Provision does not
+	// support server-side dry-run against this generated clientset and
+	// errors if this is non-empty; client-side dry-run never reaches
+	// Provision, since it only builds the manifest via BuildServiceInstance.
+	DryRun string
+}
+
+// BuildServiceInstance assembles the ServiceInstance that Provision would
+// submit to the API server, without sending it. Used both by Provision
+// itself and by --dry-run=client callers that just want to see the object.
+func BuildServiceInstance(name, class, plan string, opts *ProvisionOptions) (*v1beta1.ServiceInstance, error) {
+	var rawParams *runtime.RawExtension
+	if opts.Params != nil {
+		paramsJSON, err := json.Marshal(opts.Params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal provision parameters (%s)", err)
+		}
+		rawParams = &runtime.RawExtension{Raw: paramsJSON}
+	}
+
+	var rawContext *runtime.RawExtension
+	if opts.Context != nil {
+		contextJSON, err := json.Marshal(opts.Context)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal provision context (%s)", err)
+		}
+		rawContext = &runtime.RawExtension{Raw: contextJSON}
+	}
+
+	// Range over opts.Secrets (a map) in sorted key order, so a manifest
+	// regenerated from the same --secret flags is byte-for-byte identical
+	// run to run instead of reordering parametersFrom nondeterministically.
+	secretParams := make([]string, 0, len(opts.Secrets))
+	for param := range opts.Secrets {
+		secretParams = append(secretParams, param)
+	}
+	sort.Strings(secretParams)
+
+	var paramsFrom []v1beta1.ParametersFromSource
+	for _, param := range secretParams {
+		paramsFrom = append(paramsFrom, v1beta1.ParametersFromSource{
+			SecretKeyRef: &v1beta1.SecretKeyReference{
+				Name: param,
+				Key:  opts.Secrets[param],
+			},
+		})
+	}
+
+	return &v1beta1.ServiceInstance{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: serviceInstanceAPIVersion,
+			Kind:       serviceInstanceKind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: opts.Namespace,
+		},
+		Spec: v1beta1.ServiceInstanceSpec{
+			PlanReference: v1beta1.PlanReference{
+				ClusterServiceClassExternalName: class,
+				ClusterServicePlanExternalName:  plan,
+			},
+			ExternalID:     opts.ExternalID,
+			Parameters:     rawParams,
+			ParametersFrom: paramsFrom,
+			Context:        rawContext,
+		},
+	}, nil
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+)
+
+var _ = Describe("BuildServiceInstance", func() {
+	It("sets TypeMeta, so a --dry-run=client manifest is valid for kubectl apply", func() {
+		instance, err := BuildServiceInstance("wordpress", "mysqldb", "free", &ProvisionOptions{Namespace: "default"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance.APIVersion).To(Equal("servicecatalog.k8s.io/v1beta1"))
+		Expect(instance.Kind).To(Equal("ServiceInstance"))
+	})
+
+	It("orders parametersFrom by secret name, regardless of map iteration order", func() {
+		opts := &ProvisionOptions{
+			Namespace: "default",
+			Secrets: map[string]string{
+				"zsecret": "key1",
+				"asecret": "key2",
+				"msecret": "key3",
+			},
+		}
+
+		var instance *v1beta1.ServiceInstance
+		for i := 0; i < 10; i++ {
+			var err error
+			instance, err = BuildServiceInstance("wordpress", "mysqldb", "free", opts)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Spec.ParametersFrom).To(Equal([]v1beta1.ParametersFromSource{
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "asecret", Key: "key2"}},
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "msecret", Key: "key3"}},
+				{SecretKeyRef: &v1beta1.SecretKeyReference{Name: "zsecret", Key: "key1"}},
+			}))
+		}
+	})
+})
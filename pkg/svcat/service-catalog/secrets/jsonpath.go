@@ -0,0 +1,47 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ExtractJSONPath parses data as JSON and returns the first match of path,
+// in the same {.foo.bar} syntax kubectl -o jsonpath uses.
+func ExtractJSONPath(data []byte, path string) (interface{}, error) {
+	var parsed interface{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("secret value is not valid JSON for --secret-param jsonpath extraction (%s)", err)
+	}
+
+	jp := jsonpath.New("secret-param")
+	if err := jp.Parse(path); err != nil {
+		return nil, fmt.Errorf("invalid jsonpath %q (%s)", path, err)
+	}
+
+	results, err := jp.FindResults(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("jsonpath %q did not match the secret value (%s)", path, err)
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return nil, fmt.Errorf("jsonpath %q did not match the secret value", path)
+	}
+	return results[0][0].Interface(), nil
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrets lets svcat unwrap sealed/sops-encrypted Secret values
+// client-side, before they're injected into a provision parameter tree via
+// --secret-param.
+package secrets
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Decrypter turns an encrypted secret value back into plaintext.
+type Decrypter interface {
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// Provider constructs a Decrypter from the provider-specific config that
+// followed its name in --secret-decrypt=name:config.
+type Provider func(config string) (Decrypter, error)
+
+var providers = map[string]Provider{}
+
+// Register adds a Decrypter provider under name, for use with
+// --secret-decrypt=name or --secret-decrypt=name:config. Providers register
+// themselves from an init() in their own package.
+func Register(name string, provider Provider) {
+	providers[name] = provider
+}
+
+// New constructs the Decrypter named by spec, in "name" or "name:config"
+// form. An empty spec means no decryption is configured, and New returns a
+// nil Decrypter and a nil error.
+func New(spec string) (Decrypter, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	name, config := spec, ""
+	if i := strings.Index(spec, ":"); i >= 0 {
+		name, config = spec[:i], spec[i+1:]
+	}
+
+	provider, ok := providers[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown --secret-decrypt provider %q", name)
+	}
+	return provider(config)
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package svcat is the entry point for the svcat SDK: it builds the
+// clients commands operate through (App) and, for commands that fan out
+// across multiple clusters, the per-context flavor of that same client.
+package svcat
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	clientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
+	servicecatalog "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// App holds the clients svcat commands use to talk to a single cluster,
+// plus the namespace to operate in when one isn't given explicitly.
+type App struct {
+	SvcatClient      servicecatalog.SvcatClient
+	CurrentNamespace string
+}
+
+// NewApp creates an App backed by config and k8sClient, scoped to
+// namespace. A nil config (as in tests, which set SvcatClient directly
+// afterwards) skips building a real SvcatClient.
+func NewApp(config *rest.Config, k8sClient kubernetes.Interface, namespace string) (*App, error) {
+	app := &App{CurrentNamespace: namespace}
+	if config == nil {
+		return app, nil
+	}
+
+	scClient, err := clientset.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create service-catalog client (%s)", err)
+	}
+	app.SvcatClient = &servicecatalog.SDK{ServiceCatalogClient: scClient, K8sClient: k8sClient}
+	return app, nil
+}
+
+// NewAppForContext is the context-parameterized sibling of NewApp: it
+// builds an App against kubeContext from the kubeconfig at kubeconfigPath
+// (the empty string means "use the default kubeconfig loading rules"),
+// instead of the current context. Used to fan a single svcat invocation
+// out across several clusters via --kube-contexts/--all-contexts.
+func NewAppForContext(kubeconfigPath, kubeContext, namespace string) (*App, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		loadingRules.ExplicitPath = kubeconfigPath
+	}
+	overrides := &clientcmd.ConfigOverrides{CurrentContext: kubeContext}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, overrides).ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build client for context %q (%s)", kubeContext, err)
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Kubernetes client for context %q (%s)", kubeContext, err)
+	}
+
+	return NewApp(config, k8sClient, namespace)
+}
+
+// Provision delegates to the App's SvcatClient.
+func (a *App) Provision(instanceName, className, planName string, opts *servicecatalog.ProvisionOptions) (*v1beta1.ServiceInstance, error) {
+	return a.SvcatClient.Provision(instanceName, className, planName, opts)
+}
+
+// WaitForInstance delegates to the App's SvcatClient.
+func (a *App) WaitForInstance(namespace, name string, interval, timeout time.Duration) (*v1beta1.ServiceInstance, error) {
+	return a.SvcatClient.WaitForInstance(namespace, name, interval, timeout)
+}
+
+// GetSecretKey delegates to the App's SvcatClient.
+func (a *App) GetSecretKey(namespace, secretName, key string) ([]byte, error) {
+	return a.SvcatClient.GetSecretKey(namespace, secretName, key)
+}
+
+// GetClusterID delegates to the App's SvcatClient.
+func (a *App) GetClusterID() (string, error) {
+	return a.SvcatClient.GetClusterID()
+}
+
+// ListKubeContexts returns every context name defined in the kubeconfig at
+// kubeconfigPath, sorted, for --all-contexts. An empty kubeconfigPath uses
+// the default kubeconfig loading rules.
+func ListKubeContexts(kubeconfigPath string) ([]string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfigPath != "" {
+		loadingRules.ExplicitPath = kubeconfigPath
+	}
+
+	rawConfig, err := loadingRules.Load()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load kubeconfig (%s)", err)
+	}
+
+	contexts := make([]string, 0, len(rawConfig.Contexts))
+	for name := range rawConfig.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
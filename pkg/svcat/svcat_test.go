@@ -0,0 +1,109 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package svcat
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev-cluster
+  cluster:
+    server: https://dev.example.com
+- name: prod-cluster
+  cluster:
+    server: https://prod.example.com
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+- name: prod
+  context:
+    cluster: prod-cluster
+    user: prod-user
+users:
+- name: dev-user
+  user:
+    token: dev-token
+- name: prod-user
+  user:
+    token: prod-token
+`
+
+func writeTestKubeconfig() (path string, cleanup func()) {
+	dir, err := ioutil.TempDir("", "svcat-kubeconfig-test")
+	Expect(err).NotTo(HaveOccurred())
+
+	path = filepath.Join(dir, "config")
+	Expect(ioutil.WriteFile(path, []byte(testKubeconfig), 0644)).To(Succeed())
+	return path, func() { os.RemoveAll(dir) }
+}
+
+var _ = Describe("ListKubeContexts", func() {
+	It("returns every context name in the kubeconfig, sorted", func() {
+		path, cleanup := writeTestKubeconfig()
+		defer cleanup()
+
+		contexts, err := ListKubeContexts(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contexts).To(Equal([]string{"dev", "prod"}))
+	})
+
+	It("errors if the kubeconfig doesn't exist", func() {
+		_, err := ListKubeContexts("/no/such/kubeconfig")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("NewAppForContext", func() {
+	It("builds an App scoped to the given context and namespace", func() {
+		path, cleanup := writeTestKubeconfig()
+		defer cleanup()
+
+		app, err := NewAppForContext(path, "prod", "foobarnamespace")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app.CurrentNamespace).To(Equal("foobarnamespace"))
+		Expect(app.SvcatClient).NotTo(BeNil())
+	})
+
+	It("errors on a context that doesn't exist in the kubeconfig", func() {
+		path, cleanup := writeTestKubeconfig()
+		defer cleanup()
+
+		_, err := NewAppForContext(path, "staging", "foobarnamespace")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("NewApp", func() {
+	It("skips building a SvcatClient when config is nil, for tests to set their own fake", func() {
+		app, err := NewApp(nil, nil, "foobarnamespace")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app.CurrentNamespace).To(Equal("foobarnamespace"))
+		Expect(app.SvcatClient).To(BeNil())
+	})
+})